@@ -0,0 +1,64 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TurndownState records the pre-turndown size of every node pool a
+// TurndownSchedule touched, so the original sizing can be restored even if
+// the controller restarts mid-cycle. It replaces the earlier approach of
+// stashing min/max/count in a single cloud tag.
+type TurndownState struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TurndownStateSpec   `json:"spec"`
+	Status TurndownStateStatus `json:"status,omitempty"`
+}
+
+type TurndownStateSpec struct {
+	// ScheduleName is the TurndownSchedule this state belongs to.
+	ScheduleName string `json:"scheduleName"`
+}
+
+type TurndownStateStatus struct {
+	// SavedNodePools is the sizing each affected node pool had immediately
+	// before it was scaled down, keyed by pool name via SavedNodePool.Name.
+	SavedNodePools []SavedNodePool `json:"savedNodePools,omitempty"`
+
+	// Generation is incremented every time SavedNodePools is written. It's
+	// an audit counter, not a conflict check: lost updates are prevented by
+	// the API server's resourceVersion-based optimistic concurrency on the
+	// UpdateStatus call itself (CRDTurndownStateStore.get always round-trips
+	// the resourceVersion it read), which rejects a write based on stale
+	// state before Generation ever comes into play.
+	Generation int64 `json:"generation,omitempty"`
+}
+
+type SavedNodePool struct {
+	Name     string            `json:"name"`
+	MinNodes int32             `json:"minNodes"`
+	MaxNodes int32             `json:"maxNodes"`
+	Count    int32             `json:"count"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Taints   []v1.Taint        `json:"taints,omitempty"`
+
+	// Limits is the verbatim resource.Quantity map (e.g. {"cpu": "32",
+	// "memory": "128Gi"}) a Karpenter NodePool had before it was zeroed.
+	// Providers that size pools by node count instead of a resource budget
+	// leave this unset and use Count.
+	Limits map[string]string `json:"limits,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type TurndownStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []TurndownState `json:"items"`
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/rs/zerolog/log"
+)
+
+const AKSTurndownPoolName = "cluster-turndown"
+
+// AKSProvider is the ComputeProvider for Azure Kubernetes Service.
+type AKSProvider struct {
+	cloudNodePoolProvider
+}
+
+func NewAKSProvider(kubernetes kubernetes.Interface, clusterProvider cp.ClusterProvider, stateStore TurndownStateStore) TurndownProvider {
+	return &AKSProvider{
+		cloudNodePoolProvider: cloudNodePoolProvider{
+			kubernetes:      kubernetes,
+			clusterProvider: clusterProvider,
+			stateStore:      stateStore,
+			singletonName:   AKSTurndownPoolName,
+			drainOptions:    DefaultDrainOptions(),
+			events:          newEventRecorder(kubernetes, "AKSProvider"),
+			log:             log.With().Str("component", "AKSProvider").Logger(),
+		},
+	}
+}
+
+func (p *AKSProvider) CreateSingletonNodePool(spec *TurndownNodePoolSpec, labels map[string]string) error {
+	ctx := context.TODO()
+
+	if spec == nil {
+		spec = DefaultAKSTurndownNodePoolSpec()
+	}
+
+	return p.clusterProvider.CreateNodePool(ctx, AKSTurndownPoolName, cp.NodePoolOptions{
+		InstanceType:     spec.InstanceType,
+		NodeCount:        1,
+		DiskType:         spec.DiskType,
+		DiskSizeGB:       spec.DiskSizeGB,
+		Spot:             spec.CapacityType == CapacityTypeSpot,
+		Taints:           spec.Taints,
+		SubnetIDs:        spec.SubnetIDs,
+		SecurityGroupIDs: spec.SecurityGroupIDs,
+		Labels:           toTurndownNodePoolLabels(labels),
+	})
+}
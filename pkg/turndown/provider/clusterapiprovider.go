@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	turndownv1alpha1 "github.com/kubecost/cluster-turndown/v2/pkg/apis/turndown/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var machineDeploymentGVR = schema.GroupVersionResource{
+	Group:    "cluster.x-k8s.io",
+	Version:  "v1beta1",
+	Resource: "machinedeployments",
+}
+
+const ClusterAPITurndownNodePoolName = "cluster-turndown"
+
+// ClusterAPIProvider is a ComputeProvider for clusters managed by Cluster
+// API, where a node group is a MachineDeployment and its size is
+// spec.replicas. Unlike KarpenterProvider, replicas are a node count, so
+// SetNodePoolSizes/ResetNodePoolSizes can use SavedNodePool.Count directly
+// without any unit translation.
+type ClusterAPIProvider struct {
+	kubernetes   kubernetes.Interface
+	dynamic      dynamic.Interface
+	namespace    string
+	stateStore   TurndownStateStore
+	drainOptions DrainOptions
+	events       record.EventRecorder
+	log          zerolog.Logger
+}
+
+func NewClusterAPIProvider(kubernetes kubernetes.Interface, dynamicClient dynamic.Interface, namespace string, stateStore TurndownStateStore) TurndownProvider {
+	return &ClusterAPIProvider{
+		kubernetes:   kubernetes,
+		dynamic:      dynamicClient,
+		namespace:    namespace,
+		stateStore:   stateStore,
+		drainOptions: DefaultDrainOptions(),
+		events:       newEventRecorder(kubernetes, "ClusterAPIProvider"),
+		log:          log.With().Str("component", "ClusterAPIProvider").Logger(),
+	}
+}
+
+// SetDrainOptions overrides the PDB-aware drain behavior used before a
+// MachineDeployment is scaled to zero. Defaults to DefaultDrainOptions.
+func (p *ClusterAPIProvider) SetDrainOptions(opts DrainOptions) {
+	p.drainOptions = opts
+}
+
+func (p *ClusterAPIProvider) IsTurndownNodePool() bool {
+	_, err := p.dynamic.Resource(machineDeploymentGVR).Namespace(p.namespace).Get(context.TODO(), ClusterAPITurndownNodePoolName, metav1.GetOptions{})
+	return err == nil
+}
+
+// CreateSingletonNodePool isn't supported: a MachineDeployment needs an
+// infrastructure/bootstrap template pair that's specific to the cluster's
+// infra provider, which turndown has no generic way to synthesize. Cluster
+// API installs are expected to pre-provision the cluster-turndown
+// MachineDeployment; turndown only manages its replica count.
+func (p *ClusterAPIProvider) CreateSingletonNodePool(spec *TurndownNodePoolSpec, labels map[string]string) error {
+	return fmt.Errorf("ClusterAPIProvider requires the %s MachineDeployment to be pre-provisioned; turndown only manages its replica count", ClusterAPITurndownNodePoolName)
+}
+
+func (p *ClusterAPIProvider) GetPoolID(node *v1.Node) string {
+	return node.Labels["cluster.x-k8s.io/deployment-name"]
+}
+
+func (p *ClusterAPIProvider) GetNodePools() ([]cp.NodePool, error) {
+	return nil, fmt.Errorf("ClusterAPIProvider does not expose cloud NodePool objects, use GetPoolID against Nodes instead")
+}
+
+func (p *ClusterAPIProvider) SetNodePoolSizes(scheduleName string, nodePools []cp.NodePool, size int32) error {
+	ctx := context.TODO()
+
+	for _, np := range nodePools {
+		replicas, err := p.getReplicas(ctx, np.Name())
+		if err != nil {
+			return fmt.Errorf("reading replicas for MachineDeployment %s: %w", np.Name(), err)
+		}
+
+		if err := p.stateStore.Save(ctx, scheduleName, turndownv1alpha1.SavedNodePool{Name: np.Name(), Count: replicas}); err != nil {
+			return err
+		}
+
+		if size == 0 {
+			if err := drainNodesByLabelSelector(ctx, p.kubernetes, p.events, p.log, "cluster.x-k8s.io/deployment-name="+np.Name(), p.drainOptions); err != nil {
+				return fmt.Errorf("draining MachineDeployment %s: %w", np.Name(), err)
+			}
+		}
+
+		if err := p.setReplicas(ctx, np.Name(), size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *ClusterAPIProvider) ResetNodePoolSizes(scheduleName string, nodePools []cp.NodePool) error {
+	ctx := context.TODO()
+
+	for _, np := range nodePools {
+		saved, ok, err := p.stateStore.Load(ctx, scheduleName, np.Name())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p.log.Error().Msgf("Failed to locate saved TurndownState for NodePool: %s", np.Name())
+			continue
+		}
+
+		if err := p.setReplicas(ctx, np.Name(), saved.Count); err != nil {
+			return err
+		}
+
+		if err := p.stateStore.Delete(ctx, scheduleName, np.Name()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *ClusterAPIProvider) getReplicas(ctx context.Context, name string) (int32, error) {
+	obj, err := p.dynamic.Resource(machineDeploymentGVR).Namespace(p.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	replicas, found, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if err != nil {
+		return 0, fmt.Errorf("reading spec.replicas: %w", err)
+	}
+	if !found {
+		return 0, nil
+	}
+
+	return int32(replicas), nil
+}
+
+func (p *ClusterAPIProvider) setReplicas(ctx context.Context, name string, replicas int32) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding replicas patch: %w", err)
+	}
+
+	_, err = p.dynamic.Resource(machineDeploymentGVR).Namespace(p.namespace).Patch(ctx, name, types.MergePatchType, body, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	turndownv1alpha1 "github.com/kubecost/cluster-turndown/v2/pkg/apis/turndown/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/rs/zerolog"
+)
+
+// cloudNodePoolProvider implements the node pool lifecycle (sizing, saved
+// state, draining, additional named groups) shared by every cloud-managed
+// ComputeProvider (EKS, GKE, AKS). Each concrete provider embeds it and only
+// adds its own CreateSingletonNodePool defaults and constructor.
+type cloudNodePoolProvider struct {
+	kubernetes      kubernetes.Interface
+	clusterProvider cp.ClusterProvider
+	stateStore      TurndownStateStore
+	singletonName   string
+	drainOptions    DrainOptions
+	events          record.EventRecorder
+	log             zerolog.Logger
+}
+
+func (p *cloudNodePoolProvider) IsTurndownNodePool() bool {
+	return p.clusterProvider.IsNodePool(p.singletonName)
+}
+
+func (p *cloudNodePoolProvider) GetPoolID(node *v1.Node) string {
+	return p.clusterProvider.GetNodePoolName(node)
+}
+
+func (p *cloudNodePoolProvider) GetNodePools() ([]cp.NodePool, error) {
+	return p.clusterProvider.GetNodePools()
+}
+
+// SetDrainOptions overrides the default pre-scale-to-zero drain behavior,
+// typically sourced from the TurndownSchedule that owns these node pools.
+func (p *cloudNodePoolProvider) SetDrainOptions(opts DrainOptions) {
+	p.drainOptions = opts
+}
+
+// CreateNodePools creates each named group that doesn't yet exist, updates
+// MinNodes/MaxNodes in place when only the bounds differ, and recreates
+// (delete + create) any group whose InstanceType changed, since resizing a
+// pool in place can't change the type of nodes it launches. Groups that
+// already match spec exactly are left untouched.
+func (p *cloudNodePoolProvider) CreateNodePools(specs []NodeGroupSpec, labels map[string]string) error {
+	ctx := context.TODO()
+
+	existing, err := p.clusterProvider.GetNodePools()
+	if err != nil {
+		return fmt.Errorf("listing node pools: %w", err)
+	}
+
+	byName := make(map[string]cp.NodePool, len(existing))
+	for _, np := range existing {
+		byName[np.Name()] = np
+	}
+
+	for _, spec := range specs {
+		np, ok := byName[spec.Name]
+		if !ok {
+			if err := p.clusterProvider.CreateNodePool(ctx, spec.Name, cp.NodePoolOptions{
+				InstanceType: spec.InstanceType,
+				NodeCount:    spec.MinNodes,
+				Labels:       toTurndownNodePoolLabels(labels),
+			}); err != nil {
+				return fmt.Errorf("creating node pool %s: %w", spec.Name, err)
+			}
+
+			if err := p.applyNodePoolRange(ctx, spec); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if np.InstanceType() == spec.InstanceType && np.MinNodes() == spec.MinNodes && np.MaxNodes() == spec.MaxNodes {
+			continue
+		}
+
+		if np.InstanceType() != spec.InstanceType {
+			if err := p.clusterProvider.DeleteNodePool(ctx, spec.Name); err != nil {
+				return fmt.Errorf("deleting node pool %s for instance type change: %w", spec.Name, err)
+			}
+
+			if err := p.clusterProvider.CreateNodePool(ctx, spec.Name, cp.NodePoolOptions{
+				InstanceType: spec.InstanceType,
+				NodeCount:    spec.MinNodes,
+				Labels:       toTurndownNodePoolLabels(labels),
+			}); err != nil {
+				return fmt.Errorf("recreating node pool %s: %w", spec.Name, err)
+			}
+
+			if err := p.applyNodePoolRange(ctx, spec); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := p.clusterProvider.SetNodePoolRange(ctx, np, spec.MinNodes, spec.MaxNodes); err != nil {
+			return fmt.Errorf("updating range for node pool %s: %w", spec.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// TurndownManagedTagKey marks every node pool CreateNodePools creates. Named
+// groups use a caller-supplied name (e.g. "api") with no recognizable
+// prefix, unlike the cluster-turndown singleton, so the orphan-pool
+// reconciler needs this tag to recognize them as turndown-managed.
+const TurndownManagedTagKey = "cluster.turndown.managed"
+
+// applyNodePoolRange looks up a just-(re)created node pool by name, applies
+// its MinNodes/MaxNodes bounds (CreateNodePool only accepts a single
+// NodeCount at creation time and has no min/max of its own), and tags it so
+// the orphan-pool reconciler can find it even if it's never referenced by
+// an active TurndownSchedule.
+func (p *cloudNodePoolProvider) applyNodePoolRange(ctx context.Context, spec NodeGroupSpec) error {
+	pools, err := p.clusterProvider.GetNodePools()
+	if err != nil {
+		return fmt.Errorf("listing node pools: %w", err)
+	}
+
+	for _, np := range pools {
+		if np.Name() != spec.Name {
+			continue
+		}
+
+		if err := p.clusterProvider.SetNodePoolRange(ctx, np, spec.MinNodes, spec.MaxNodes); err != nil {
+			return fmt.Errorf("setting range for node pool %s: %w", spec.Name, err)
+		}
+
+		if err := p.clusterProvider.SetTags(ctx, np, map[string]string{TurndownManagedTagKey: "true"}); err != nil {
+			return fmt.Errorf("tagging node pool %s: %w", spec.Name, err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("node pool %s not found immediately after creation", spec.Name)
+}
+
+// DeleteNodePools removes the node pools with the given names, ignoring
+// names that no longer exist.
+func (p *cloudNodePoolProvider) DeleteNodePools(names []string) error {
+	ctx := context.TODO()
+
+	for _, name := range names {
+		if err := p.clusterProvider.DeleteNodePool(ctx, name); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting node pool %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *cloudNodePoolProvider) SetNodePoolSizes(scheduleName string, nodePools []cp.NodePool, size int32) error {
+	if len(nodePools) == 0 {
+		return nil
+	}
+
+	c, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	if size == 0 {
+		if err := p.drainNodePools(c, nodePools, p.drainOptions); err != nil {
+			p.log.Error().Msgf("Draining NodePools: %s", err.Error())
+			return err
+		}
+	}
+
+	for _, np := range nodePools {
+		saved := turndownv1alpha1.SavedNodePool{
+			Name:     np.Name(),
+			MinNodes: np.MinNodes(),
+			MaxNodes: np.MaxNodes(),
+			Count:    np.NodeCount(),
+		}
+
+		// Save the restore point before resizing, so a crash between the two
+		// calls still leaves a record UpdateNodePoolSize can be retried
+		// against, instead of stranding the pool at its scaled-down size.
+		if err := p.stateStore.Save(c, scheduleName, saved); err != nil {
+			p.log.Error().Msgf("Saving TurndownState for pool %s: %s", np.Name(), err.Error())
+			return err
+		}
+
+		if err := p.clusterProvider.UpdateNodePoolSize(c, np, size); err != nil {
+			p.log.Error().Msgf("Updating NodePool: %s", err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *cloudNodePoolProvider) ResetNodePoolSizes(scheduleName string, nodePools []cp.NodePool) error {
+	if len(nodePools) == 0 {
+		return nil
+	}
+
+	c, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	for _, np := range nodePools {
+		saved, ok, err := p.stateStore.Load(c, scheduleName, np.Name())
+		if err != nil {
+			p.log.Error().Msgf("Loading TurndownState for pool %s: %s", np.Name(), err.Error())
+			return err
+		}
+
+		if !ok {
+			// Fall back to the legacy cluster.turndown.previous tag for
+			// pools that were scaled down before the TurndownState CRD
+			// existed.
+			legacy, legacyOK := legacySavedNodePool(np)
+			if !legacyOK {
+				p.log.Error().Msgf("Failed to locate saved TurndownState for NodePool: %s", np.Name())
+				continue
+			}
+
+			saved = legacy
+		}
+
+		if err := p.clusterProvider.UpdateNodePoolSize(c, np, saved.Count); err != nil {
+			p.log.Error().Msgf("Updating NodePool: %s", err.Error())
+			return err
+		}
+
+		if ok {
+			if err := p.stateStore.Delete(c, scheduleName, np.Name()); err != nil {
+				p.log.Error().Msgf("Deleting TurndownState for pool %s: %s", np.Name(), err.Error())
+				return err
+			}
+		} else if err := p.clusterProvider.DeleteTags(c, np, []string{EKSNodeGroupPreviousKey}); err != nil {
+			p.log.Error().Msgf("Deleting legacy tag for pool %s: %s", np.Name(), err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// drainNodePools cordons and evicts every pod on every node belonging to
+// nodePools, respecting PodDisruptionBudgets, before the pools are resized
+// to zero.
+func (p *cloudNodePoolProvider) drainNodePools(ctx context.Context, nodePools []cp.NodePool, opts DrainOptions) error {
+	nodes, err := p.kubernetes.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing nodes: %w", err)
+	}
+
+	poolNames := make(map[string]bool, len(nodePools))
+	for _, np := range nodePools {
+		poolNames[np.Name()] = true
+	}
+
+	for _, node := range nodes.Items {
+		if !poolNames[p.GetPoolID(&node)] {
+			continue
+		}
+
+		if err := drainNode(ctx, p.kubernetes, p.events, p.log, node.Name, opts); err != nil {
+			return fmt.Errorf("draining node %s: %w", node.Name, err)
+		}
+	}
+
+	return nil
+}
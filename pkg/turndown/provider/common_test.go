@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// testPool is a settable cp.NodePool stand-in, distinct from reconciler_test.go's
+// read-only fakeNodePool, used to exercise CreateNodePools' decision logic.
+type testPool struct {
+	name         string
+	instanceType string
+	min, max     int32
+	count        int32
+}
+
+func (p testPool) Name() string            { return p.name }
+func (p testPool) MinNodes() int32         { return p.min }
+func (p testPool) MaxNodes() int32         { return p.max }
+func (p testPool) NodeCount() int32        { return p.count }
+func (p testPool) InstanceType() string    { return p.instanceType }
+func (p testPool) Tags() map[string]string { return nil }
+func (p testPool) CreatedAt() time.Time    { return time.Time{} }
+
+// fakeClusterProvider records the calls CreateNodePools/DeleteNodePools make
+// against cp.ClusterProvider, seeded with whatever pools already "exist".
+type fakeClusterProvider struct {
+	pools map[string]testPool
+
+	created  []string
+	deleted  []string
+	ranged   map[string][2]int32
+	tagged   map[string]map[string]string
+	notFound map[string]bool
+}
+
+func newFakeClusterProvider(pools ...testPool) *fakeClusterProvider {
+	byName := make(map[string]testPool, len(pools))
+	for _, p := range pools {
+		byName[p.name] = p
+	}
+
+	return &fakeClusterProvider{
+		pools:    byName,
+		ranged:   map[string][2]int32{},
+		notFound: map[string]bool{},
+	}
+}
+
+func (f *fakeClusterProvider) CreateNodePool(ctx context.Context, name string, opts cp.NodePoolOptions) error {
+	f.created = append(f.created, name)
+	f.pools[name] = testPool{name: name, instanceType: opts.InstanceType, count: opts.NodeCount}
+	return nil
+}
+
+func (f *fakeClusterProvider) DeleteNodePool(ctx context.Context, name string) error {
+	f.deleted = append(f.deleted, name)
+	if _, ok := f.pools[name]; !ok {
+		if f.notFound[name] {
+			return apierrors.NewNotFound(schema.GroupResource{Resource: "nodepools"}, name)
+		}
+		return nil
+	}
+	delete(f.pools, name)
+	return nil
+}
+
+func (f *fakeClusterProvider) DeleteTags(ctx context.Context, np cp.NodePool, keys []string) error {
+	return nil
+}
+
+func (f *fakeClusterProvider) SetTags(ctx context.Context, np cp.NodePool, tags map[string]string) error {
+	if f.tagged == nil {
+		f.tagged = map[string]map[string]string{}
+	}
+	f.tagged[np.Name()] = tags
+	return nil
+}
+
+func (f *fakeClusterProvider) GetNodePoolName(node *v1.Node) string { return "" }
+
+func (f *fakeClusterProvider) GetNodePools() ([]cp.NodePool, error) {
+	pools := make([]cp.NodePool, 0, len(f.pools))
+	for _, p := range f.pools {
+		pools = append(pools, p)
+	}
+	return pools, nil
+}
+
+func (f *fakeClusterProvider) IsNodePool(name string) bool {
+	_, ok := f.pools[name]
+	return ok
+}
+
+func (f *fakeClusterProvider) SetNodePoolRange(ctx context.Context, np cp.NodePool, min, max int32) error {
+	f.ranged[np.Name()] = [2]int32{min, max}
+	if p, ok := f.pools[np.Name()]; ok {
+		p.min, p.max = min, max
+		f.pools[np.Name()] = p
+	}
+	return nil
+}
+
+func (f *fakeClusterProvider) UpdateNodePoolSize(ctx context.Context, np cp.NodePool, size int32) error {
+	return nil
+}
+
+func TestCreateNodePoolsAppliesRangeOnCreate(t *testing.T) {
+	cluster := newFakeClusterProvider()
+	p := &cloudNodePoolProvider{clusterProvider: cluster}
+
+	if err := p.CreateNodePools([]NodeGroupSpec{{Name: "api", InstanceType: "m5.large", MinNodes: 1, MaxNodes: 5}}, nil); err != nil {
+		t.Fatalf("CreateNodePools: unexpected error: %s", err.Error())
+	}
+
+	if got, want := cluster.ranged["api"], ([2]int32{1, 5}); got != want {
+		t.Errorf("SetNodePoolRange(api) = %v, want %v", got, want)
+	}
+	if cluster.tagged["api"][TurndownManagedTagKey] != "true" {
+		t.Errorf("expected api to carry %s=true, got %v", TurndownManagedTagKey, cluster.tagged["api"])
+	}
+}
+
+func TestCreateNodePoolsAppliesRangeOnRecreate(t *testing.T) {
+	cluster := newFakeClusterProvider(testPool{name: "api", instanceType: "m5.large", min: 1, max: 3, count: 1})
+	p := &cloudNodePoolProvider{clusterProvider: cluster}
+
+	if err := p.CreateNodePools([]NodeGroupSpec{{Name: "api", InstanceType: "m5.xlarge", MinNodes: 2, MaxNodes: 6}}, nil); err != nil {
+		t.Fatalf("CreateNodePools: unexpected error: %s", err.Error())
+	}
+
+	if len(cluster.deleted) != 1 || cluster.deleted[0] != "api" {
+		t.Errorf("expected api to be deleted for instance type change, deleted = %v", cluster.deleted)
+	}
+	if got, want := cluster.ranged["api"], ([2]int32{2, 6}); got != want {
+		t.Errorf("SetNodePoolRange(api) after recreate = %v, want %v", got, want)
+	}
+}
+
+func TestCreateNodePoolsUpdatesRangeInPlace(t *testing.T) {
+	cluster := newFakeClusterProvider(testPool{name: "api", instanceType: "m5.large", min: 1, max: 3, count: 1})
+	p := &cloudNodePoolProvider{clusterProvider: cluster}
+
+	if err := p.CreateNodePools([]NodeGroupSpec{{Name: "api", InstanceType: "m5.large", MinNodes: 1, MaxNodes: 10}}, nil); err != nil {
+		t.Fatalf("CreateNodePools: unexpected error: %s", err.Error())
+	}
+
+	if len(cluster.deleted) != 0 {
+		t.Errorf("expected no delete for a bounds-only change, deleted = %v", cluster.deleted)
+	}
+	if got, want := cluster.ranged["api"], ([2]int32{1, 10}); got != want {
+		t.Errorf("SetNodePoolRange(api) = %v, want %v", got, want)
+	}
+}
+
+func TestCreateNodePoolsSkipsUnchanged(t *testing.T) {
+	cluster := newFakeClusterProvider(testPool{name: "api", instanceType: "m5.large", min: 1, max: 3, count: 1})
+	p := &cloudNodePoolProvider{clusterProvider: cluster}
+
+	if err := p.CreateNodePools([]NodeGroupSpec{{Name: "api", InstanceType: "m5.large", MinNodes: 1, MaxNodes: 3}}, nil); err != nil {
+		t.Fatalf("CreateNodePools: unexpected error: %s", err.Error())
+	}
+
+	if len(cluster.created) != 0 || len(cluster.deleted) != 0 || len(cluster.ranged) != 0 {
+		t.Errorf("expected no-op for an unchanged spec, created=%v deleted=%v ranged=%v", cluster.created, cluster.deleted, cluster.ranged)
+	}
+}
+
+func TestDeleteNodePoolsIgnoresNotFound(t *testing.T) {
+	cluster := newFakeClusterProvider()
+	cluster.notFound["gone"] = true
+	p := &cloudNodePoolProvider{clusterProvider: cluster}
+
+	if err := p.DeleteNodePools([]string{"gone"}); err != nil {
+		t.Errorf("DeleteNodePools: expected not-found to be ignored, got error: %s", err.Error())
+	}
+}
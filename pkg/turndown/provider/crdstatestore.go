@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	turndownv1alpha1 "github.com/kubecost/cluster-turndown/v2/pkg/apis/turndown/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var turndownStateGVR = schema.GroupVersionResource{
+	Group:    "turndown.kubecost.com",
+	Version:  "v1alpha1",
+	Resource: "turndownstates",
+}
+
+// CRDTurndownStateStore is the concrete TurndownStateStore backing used in
+// production: it reads/writes a single TurndownState custom resource per
+// schedule, one SavedNodePool entry per node pool that schedule touched.
+type CRDTurndownStateStore struct {
+	dynamic   dynamic.Interface
+	namespace string
+}
+
+func NewCRDTurndownStateStore(dynamicClient dynamic.Interface, namespace string) *CRDTurndownStateStore {
+	return &CRDTurndownStateStore{dynamic: dynamicClient, namespace: namespace}
+}
+
+func (s *CRDTurndownStateStore) Save(ctx context.Context, scheduleName string, pool turndownv1alpha1.SavedNodePool) error {
+	state, err := s.getOrCreate(ctx, scheduleName)
+	if err != nil {
+		return fmt.Errorf("getting TurndownState %s: %w", scheduleName, err)
+	}
+
+	upserted := false
+	for i := range state.Status.SavedNodePools {
+		if state.Status.SavedNodePools[i].Name == pool.Name {
+			state.Status.SavedNodePools[i] = pool
+			upserted = true
+			break
+		}
+	}
+	if !upserted {
+		state.Status.SavedNodePools = append(state.Status.SavedNodePools, pool)
+	}
+	state.Status.Generation++
+
+	return s.updateStatus(ctx, state)
+}
+
+func (s *CRDTurndownStateStore) Load(ctx context.Context, scheduleName, poolName string) (turndownv1alpha1.SavedNodePool, bool, error) {
+	state, err := s.get(ctx, scheduleName)
+	if apierrors.IsNotFound(err) {
+		return turndownv1alpha1.SavedNodePool{}, false, nil
+	}
+	if err != nil {
+		return turndownv1alpha1.SavedNodePool{}, false, fmt.Errorf("getting TurndownState %s: %w", scheduleName, err)
+	}
+
+	for _, saved := range state.Status.SavedNodePools {
+		if saved.Name == poolName {
+			return saved, true, nil
+		}
+	}
+
+	return turndownv1alpha1.SavedNodePool{}, false, nil
+}
+
+func (s *CRDTurndownStateStore) Delete(ctx context.Context, scheduleName, poolName string) error {
+	state, err := s.get(ctx, scheduleName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("getting TurndownState %s: %w", scheduleName, err)
+	}
+
+	remaining := state.Status.SavedNodePools[:0]
+	for _, saved := range state.Status.SavedNodePools {
+		if saved.Name != poolName {
+			remaining = append(remaining, saved)
+		}
+	}
+	state.Status.SavedNodePools = remaining
+	state.Status.Generation++
+
+	return s.updateStatus(ctx, state)
+}
+
+func (s *CRDTurndownStateStore) get(ctx context.Context, scheduleName string) (*turndownv1alpha1.TurndownState, error) {
+	obj, err := s.dynamic.Resource(turndownStateGVR).Namespace(s.namespace).Get(ctx, scheduleName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	state := &turndownv1alpha1.TurndownState{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, state); err != nil {
+		return nil, fmt.Errorf("decoding TurndownState %s: %w", scheduleName, err)
+	}
+
+	return state, nil
+}
+
+func (s *CRDTurndownStateStore) getOrCreate(ctx context.Context, scheduleName string) (*turndownv1alpha1.TurndownState, error) {
+	state, err := s.get(ctx, scheduleName)
+	if err == nil {
+		return state, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	state = &turndownv1alpha1.TurndownState{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: turndownStateGVR.GroupVersion().String(),
+			Kind:       "TurndownState",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scheduleName,
+			Namespace: s.namespace,
+		},
+		Spec: turndownv1alpha1.TurndownStateSpec{ScheduleName: scheduleName},
+	}
+
+	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(state)
+	if err != nil {
+		return nil, fmt.Errorf("encoding TurndownState %s: %w", scheduleName, err)
+	}
+
+	created, err := s.dynamic.Resource(turndownStateGVR).Namespace(s.namespace).Create(ctx, &unstructured.Unstructured{Object: unstructuredObj}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the server's copy so the resourceVersion it assigned is carried
+	// into the UpdateStatus call that follows.
+	state = &turndownv1alpha1.TurndownState{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(created.Object, state); err != nil {
+		return nil, fmt.Errorf("decoding TurndownState %s: %w", scheduleName, err)
+	}
+
+	return state, nil
+}
+
+func (s *CRDTurndownStateStore) updateStatus(ctx context.Context, state *turndownv1alpha1.TurndownState) error {
+	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(state)
+	if err != nil {
+		return fmt.Errorf("encoding TurndownState %s: %w", state.Name, err)
+	}
+
+	_, err = s.dynamic.Resource(turndownStateGVR).Namespace(s.namespace).UpdateStatus(ctx, &unstructured.Unstructured{Object: unstructuredObj}, metav1.UpdateOptions{})
+	return err
+}
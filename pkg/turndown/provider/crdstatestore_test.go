@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	turndownv1alpha1 "github.com/kubecost/cluster-turndown/v2/pkg/apis/turndown/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeStateStore(t *testing.T) *CRDTurndownStateStore {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := turndownv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("registering scheme: %s", err.Error())
+	}
+
+	listKinds := map[schema.GroupVersionResource]string{
+		turndownStateGVR: "TurndownStateList",
+	}
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds)
+	return NewCRDTurndownStateStore(client, "kubecost")
+}
+
+func TestCRDTurndownStateStoreSaveLoadDelete(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeStateStore(t)
+
+	if _, ok, err := store.Load(ctx, "nightly", "api"); err != nil {
+		t.Fatalf("Load on empty store: unexpected error: %s", err.Error())
+	} else if ok {
+		t.Fatal("Load on empty store: expected ok=false")
+	}
+
+	pool := turndownv1alpha1.SavedNodePool{Name: "api", MinNodes: 1, MaxNodes: 3, Count: 2}
+	if err := store.Save(ctx, "nightly", pool); err != nil {
+		t.Fatalf("Save: unexpected error: %s", err.Error())
+	}
+
+	got, ok, err := store.Load(ctx, "nightly", "api")
+	if err != nil {
+		t.Fatalf("Load after Save: unexpected error: %s", err.Error())
+	}
+	if !ok {
+		t.Fatal("Load after Save: expected ok=true")
+	}
+	if !reflect.DeepEqual(got, pool) {
+		t.Errorf("Load after Save = %+v, want %+v", got, pool)
+	}
+
+	updated := turndownv1alpha1.SavedNodePool{Name: "api", MinNodes: 1, MaxNodes: 3, Count: 5}
+	if err := store.Save(ctx, "nightly", updated); err != nil {
+		t.Fatalf("Save (upsert): unexpected error: %s", err.Error())
+	}
+	if got, _, _ := store.Load(ctx, "nightly", "api"); !reflect.DeepEqual(got, updated) {
+		t.Errorf("Load after upsert = %+v, want %+v", got, updated)
+	}
+
+	if err := store.Delete(ctx, "nightly", "api"); err != nil {
+		t.Fatalf("Delete: unexpected error: %s", err.Error())
+	}
+	if _, ok, _ := store.Load(ctx, "nightly", "api"); ok {
+		t.Error("Load after Delete: expected ok=false")
+	}
+}
+
+func TestCRDTurndownStateStoreDeleteMissingIsNoop(t *testing.T) {
+	store := newFakeStateStore(t)
+
+	if err := store.Delete(context.Background(), "nightly", "api"); err != nil {
+		t.Fatalf("Delete on missing TurndownState: unexpected error: %s", err.Error())
+	}
+}
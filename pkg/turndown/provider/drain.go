@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/rs/zerolog"
+)
+
+// DrainOptions controls how aggressively a node is drained before its pool
+// is scaled down. These mirror the `kubectl drain` flags of the same name
+// and are expected to be sourced from a TurndownSchedule.
+type DrainOptions struct {
+	// DrainTimeout is how long to wait for evicted pods to terminate before
+	// Force takes over.
+	DrainTimeout time.Duration
+	// Force deletes pods that are still running once DrainTimeout elapses,
+	// instead of returning an error.
+	Force bool
+	// DeleteEmptyDirData allows evicting pods that use emptyDir volumes.
+	// When false (the kubectl drain default), such pods are left running
+	// and a Warning event is emitted instead.
+	DeleteEmptyDirData bool
+	// IgnoreDaemonSets skips pods owned by a DaemonSet, since evicting them
+	// has no lasting effect (the DaemonSet controller just recreates them).
+	IgnoreDaemonSets bool
+}
+
+// DefaultDrainOptions returns a 5 minute DrainTimeout with Force and
+// IgnoreDaemonSets enabled and DeleteEmptyDirData disabled. Unlike
+// `kubectl drain`'s defaults, it force-deletes pods left after the timeout
+// and skips DaemonSet pods.
+func DefaultDrainOptions() DrainOptions {
+	return DrainOptions{
+		DrainTimeout:     5 * time.Minute,
+		Force:            true,
+		IgnoreDaemonSets: true,
+	}
+}
+
+// drainNodesByLabelSelector cordons and evicts every pod on every node
+// matching labelSelector, respecting PodDisruptionBudgets. It's the
+// label-based equivalent of cloudNodePoolProvider.drainNodePools, for
+// providers (Karpenter, Cluster API) that identify a node pool's nodes by a
+// label rather than by a cp.NodePool/GetPoolID lookup.
+func drainNodesByLabelSelector(ctx context.Context, kubeClient kubernetes.Interface, events record.EventRecorder, log zerolog.Logger, labelSelector string, opts DrainOptions) error {
+	nodes, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return fmt.Errorf("listing nodes: %w", err)
+	}
+
+	for _, node := range nodes.Items {
+		if err := drainNode(ctx, kubeClient, events, log, node.Name, opts); err != nil {
+			return fmt.Errorf("draining node %s: %w", node.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// drainNode cordons node and evicts every evictable pod running on it,
+// honoring any PodDisruptionBudgets that apply. It blocks until every pod
+// is gone, DrainTimeout elapses, or an unrecoverable error occurs.
+func drainNode(ctx context.Context, kubeClient kubernetes.Interface, recorder record.EventRecorder, log zerolog.Logger, nodeName string, opts DrainOptions) error {
+	nodeRef := &v1.ObjectReference{Kind: "Node", Name: nodeName}
+
+	if err := cordonNode(ctx, kubeClient, nodeName); err != nil {
+		return fmt.Errorf("cordoning node %s: %w", nodeName, err)
+	}
+	recorder.Eventf(nodeRef, v1.EventTypeNormal, "Cordoned", "Cordoned for turndown drain")
+
+	candidates, err := evictablePods(ctx, kubeClient, nodeName, opts)
+	if err != nil {
+		return fmt.Errorf("listing pods on node %s: %w", nodeName, err)
+	}
+
+	pods := make([]v1.Pod, 0, len(candidates))
+	for _, pod := range candidates {
+		if !opts.DeleteEmptyDirData && hasEmptyDirVolume(&pod) {
+			recorder.Eventf(&pod, v1.EventTypeWarning, "DrainSkipped", "Not evicting: pod uses an emptyDir volume and DeleteEmptyDirData is disabled")
+			log.Warn().Msgf("Skipping eviction of pod %s/%s: uses emptyDir and DeleteEmptyDirData is disabled", pod.Namespace, pod.Name)
+			continue
+		}
+
+		pods = append(pods, pod)
+	}
+
+	for _, pod := range pods {
+		if err := evictPod(ctx, kubeClient, pod); err != nil {
+			recorder.Eventf(&pod, v1.EventTypeWarning, "EvictFailed", "Eviction failed: %s", err.Error())
+			log.Error().Msgf("Evicting pod %s/%s: %s", pod.Namespace, pod.Name, err.Error())
+			continue
+		}
+
+		recorder.Eventf(&pod, v1.EventTypeNormal, "Evicted", "Evicted for turndown drain")
+	}
+
+	deadline := time.Now().Add(opts.DrainTimeout)
+	for {
+		remaining, err := podsStillOnNode(ctx, kubeClient, nodeName, pods)
+		if err != nil {
+			return fmt.Errorf("polling pods on node %s: %w", nodeName, err)
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if !opts.Force {
+				return fmt.Errorf("timed out waiting for %d pod(s) to terminate on node %s", len(remaining), nodeName)
+			}
+
+			for _, pod := range remaining {
+				log.Warn().Msgf("Force deleting pod %s/%s after drain timeout on node %s", pod.Namespace, pod.Name, nodeName)
+				recorder.Eventf(&pod, v1.EventTypeWarning, "ForceDeleted", "Force deleted after drain timeout of %s", opts.DrainTimeout)
+
+				gracePeriod := int64(0)
+				if delErr := kubeClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}); delErr != nil && !apierrors.IsNotFound(delErr) {
+					log.Error().Msgf("Force deleting pod %s/%s: %s", pod.Namespace, pod.Name, delErr.Error())
+				}
+			}
+
+			return nil
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func cordonNode(ctx context.Context, kubeClient kubernetes.Interface, nodeName string) error {
+	patch := []byte(`{"spec":{"unschedulable":true}}`)
+	_, err := kubeClient.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func evictablePods(ctx context.Context, kubeClient kubernetes.Interface, nodeName string, opts DrainOptions) ([]v1.Pod, error) {
+	podList, err := kubeClient.CoreV1().Pods(v1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]v1.Pod, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if isMirrorPod(&pod) {
+			continue
+		}
+		if opts.IgnoreDaemonSets && isDaemonSetPod(&pod) {
+			continue
+		}
+
+		pods = append(pods, pod)
+	}
+
+	return pods, nil
+}
+
+func isMirrorPod(pod *v1.Pod) bool {
+	_, ok := pod.Annotations[v1.MirrorPodAnnotationKey]
+	return ok
+}
+
+func isDaemonSetPod(pod *v1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasEmptyDirVolume(pod *v1.Pod) bool {
+	for _, vol := range pod.Spec.Volumes {
+		if vol.EmptyDir != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evictPod requests eviction through the Eviction subresource so that the
+// API server enforces any PodDisruptionBudget covering pod.
+func evictPod(ctx context.Context, kubeClient kubernetes.Interface, pod v1.Pod) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+
+	err := kubeClient.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+func podsStillOnNode(ctx context.Context, kubeClient kubernetes.Interface, nodeName string, original []v1.Pod) ([]v1.Pod, error) {
+	remaining := make([]v1.Pod, 0)
+	for _, pod := range original {
+		p, err := kubeClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p.Spec.NodeName != nodeName {
+			continue
+		}
+
+		remaining = append(remaining, *p)
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsDaemonSetPod(t *testing.T) {
+	cases := []struct {
+		name string
+		pod  *v1.Pod
+		want bool
+	}{
+		{
+			name: "daemonset owner",
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "deployment owner",
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet"}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "no owner",
+			pod:  &v1.Pod{},
+			want: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isDaemonSetPod(c.pod); got != c.want {
+				t.Errorf("isDaemonSetPod() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestIsMirrorPod(t *testing.T) {
+	mirror := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{v1.MirrorPodAnnotationKey: "true"},
+		},
+	}
+	plain := &v1.Pod{}
+
+	if !isMirrorPod(mirror) {
+		t.Error("expected mirror pod to be detected")
+	}
+	if isMirrorPod(plain) {
+		t.Error("expected plain pod not to be detected as mirror")
+	}
+}
+
+func TestHasEmptyDirVolume(t *testing.T) {
+	withEmptyDir := &v1.Pod{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+	withoutEmptyDir := &v1.Pod{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}},
+			},
+		},
+	}
+
+	if !hasEmptyDirVolume(withEmptyDir) {
+		t.Error("expected emptyDir volume to be detected")
+	}
+	if hasEmptyDirVolume(withoutEmptyDir) {
+		t.Error("expected no emptyDir volume to be detected")
+	}
+}
+
+func TestDefaultDrainOptions(t *testing.T) {
+	opts := DefaultDrainOptions()
+
+	if !opts.Force {
+		t.Error("expected Force to default to true")
+	}
+	if !opts.IgnoreDaemonSets {
+		t.Error("expected IgnoreDaemonSets to default to true")
+	}
+	if opts.DeleteEmptyDirData {
+		t.Error("expected DeleteEmptyDirData to default to false")
+	}
+	if opts.DrainTimeout <= 0 {
+		t.Error("expected a positive default DrainTimeout")
+	}
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	v1 "k8s.io/api/core/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+)
+
+// newEventRecorder builds an EventRecorder that posts to the cluster's
+// Event API under the cluster-turndown component, the same way a
+// kube-controller-manager style controller would.
+func newEventRecorder(kubeClient kubernetes.Interface, component string) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+
+	return broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: component})
+}
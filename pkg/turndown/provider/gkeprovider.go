@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/rs/zerolog/log"
+)
+
+const GKETurndownPoolName = "cluster-turndown"
+
+// GKEProvider is the ComputeProvider for Google Kubernetes Engine.
+type GKEProvider struct {
+	cloudNodePoolProvider
+}
+
+func NewGKEProvider(kubernetes kubernetes.Interface, clusterProvider cp.ClusterProvider, stateStore TurndownStateStore) TurndownProvider {
+	return &GKEProvider{
+		cloudNodePoolProvider: cloudNodePoolProvider{
+			kubernetes:      kubernetes,
+			clusterProvider: clusterProvider,
+			stateStore:      stateStore,
+			singletonName:   GKETurndownPoolName,
+			drainOptions:    DefaultDrainOptions(),
+			events:          newEventRecorder(kubernetes, "GKEProvider"),
+			log:             log.With().Str("component", "GKEProvider").Logger(),
+		},
+	}
+}
+
+func (p *GKEProvider) CreateSingletonNodePool(spec *TurndownNodePoolSpec, labels map[string]string) error {
+	ctx := context.TODO()
+
+	if spec == nil {
+		spec = DefaultGKETurndownNodePoolSpec()
+	}
+
+	return p.clusterProvider.CreateNodePool(ctx, GKETurndownPoolName, cp.NodePoolOptions{
+		InstanceType:     spec.InstanceType,
+		NodeCount:        1,
+		DiskType:         spec.DiskType,
+		DiskSizeGB:       spec.DiskSizeGB,
+		Spot:             spec.CapacityType == CapacityTypeSpot,
+		Taints:           spec.Taints,
+		SubnetIDs:        spec.SubnetIDs,
+		SecurityGroupIDs: spec.SecurityGroupIDs,
+		Labels:           toTurndownNodePoolLabels(labels),
+	})
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	turndownv1alpha1 "github.com/kubecost/cluster-turndown/v2/pkg/apis/turndown/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var karpenterNodePoolGVR = schema.GroupVersionResource{
+	Group:    "karpenter.sh",
+	Version:  "v1",
+	Resource: "nodepools",
+}
+
+var karpenterNodeClaimGVR = schema.GroupVersionResource{
+	Group:    "karpenter.sh",
+	Version:  "v1",
+	Resource: "nodeclaims",
+}
+
+const KarpenterTurndownNodePoolName = "cluster-turndown"
+
+// KarpenterProvider is a ComputeProvider for clusters where nodes are
+// provisioned by Karpenter rather than a cloud-managed node group. Karpenter
+// has no "set to N nodes" primitive: a NodePool's spec.limits is a resource
+// budget (cpu/memory), not a node count. "Scale to zero" is implemented by
+// saving that budget, zeroing spec.limits so Karpenter stops provisioning
+// against the pool, and deleting its NodeClaims; turn-up restores the saved
+// budget verbatim. Resizing to any other node count isn't supported.
+type KarpenterProvider struct {
+	kubernetes   kubernetes.Interface
+	dynamic      dynamic.Interface
+	stateStore   TurndownStateStore
+	drainOptions DrainOptions
+	events       record.EventRecorder
+	log          zerolog.Logger
+}
+
+func NewKarpenterProvider(kubernetes kubernetes.Interface, dynamicClient dynamic.Interface, stateStore TurndownStateStore) TurndownProvider {
+	return &KarpenterProvider{
+		kubernetes:   kubernetes,
+		dynamic:      dynamicClient,
+		stateStore:   stateStore,
+		drainOptions: DefaultDrainOptions(),
+		events:       newEventRecorder(kubernetes, "KarpenterProvider"),
+		log:          log.With().Str("component", "KarpenterProvider").Logger(),
+	}
+}
+
+// SetDrainOptions overrides the PDB-aware drain behavior used before a
+// NodePool's NodeClaims are deleted. Defaults to DefaultDrainOptions.
+func (p *KarpenterProvider) SetDrainOptions(opts DrainOptions) {
+	p.drainOptions = opts
+}
+
+func (p *KarpenterProvider) IsTurndownNodePool() bool {
+	_, err := p.dynamic.Resource(karpenterNodePoolGVR).Get(context.TODO(), KarpenterTurndownNodePoolName, metav1.GetOptions{})
+	return err == nil
+}
+
+func (p *KarpenterProvider) CreateSingletonNodePool(spec *TurndownNodePoolSpec, labels map[string]string) error {
+	if spec == nil {
+		spec = &TurndownNodePoolSpec{}
+	}
+
+	requirements := []interface{}{}
+	if spec.InstanceType != "" {
+		requirements = append(requirements, map[string]interface{}{
+			"key":      "node.kubernetes.io/instance-type",
+			"operator": "In",
+			"values":   []interface{}{spec.InstanceType},
+		})
+	}
+
+	nodePool := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "karpenter.sh/v1",
+			"kind":       "NodePool",
+			"metadata": map[string]interface{}{
+				"name":   KarpenterTurndownNodePoolName,
+				"labels": toTurndownNodePoolLabels(labels),
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"requirements": requirements,
+					},
+				},
+			},
+		},
+	}
+
+	_, err := p.dynamic.Resource(karpenterNodePoolGVR).Create(context.TODO(), nodePool, metav1.CreateOptions{})
+	return err
+}
+
+func (p *KarpenterProvider) GetPoolID(node *v1.Node) string {
+	return node.Labels["karpenter.sh/nodepool"]
+}
+
+func (p *KarpenterProvider) GetNodePools() ([]cp.NodePool, error) {
+	return nil, fmt.Errorf("KarpenterProvider does not expose cloud NodePool objects, use GetPoolID against Nodes instead")
+}
+
+// SetNodePoolSizes only supports scaling to zero: Karpenter sizes a pool by
+// a cpu/memory budget, not a node count, so there's no sound way to turn an
+// arbitrary turndown `size` into spec.limits.
+func (p *KarpenterProvider) SetNodePoolSizes(scheduleName string, nodePools []cp.NodePool, size int32) error {
+	ctx := context.TODO()
+
+	if size != 0 {
+		return fmt.Errorf("KarpenterProvider only supports scaling to zero, not to %d nodes", size)
+	}
+
+	for _, np := range nodePools {
+		if err := p.scaleNodePoolToZero(ctx, scheduleName, np.Name()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *KarpenterProvider) ResetNodePoolSizes(scheduleName string, nodePools []cp.NodePool) error {
+	ctx := context.TODO()
+
+	for _, np := range nodePools {
+		saved, ok, err := p.stateStore.Load(ctx, scheduleName, np.Name())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p.log.Error().Msgf("Failed to locate saved TurndownState for NodePool: %s", np.Name())
+			continue
+		}
+
+		if err := p.setNodePoolLimits(ctx, np.Name(), saved.Limits); err != nil {
+			return err
+		}
+
+		if err := p.stateStore.Delete(ctx, scheduleName, np.Name()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *KarpenterProvider) scaleNodePoolToZero(ctx context.Context, scheduleName, name string) error {
+	limits, err := p.getNodePoolLimits(ctx, name)
+	if err != nil {
+		return fmt.Errorf("reading limits for node pool %s: %w", name, err)
+	}
+
+	if err := p.stateStore.Save(ctx, scheduleName, turndownv1alpha1.SavedNodePool{Name: name, Limits: limits}); err != nil {
+		return err
+	}
+
+	zeroed := map[string]string{"cpu": "0"}
+	for key := range limits {
+		zeroed[key] = "0"
+	}
+
+	if err := p.setNodePoolLimits(ctx, name, zeroed); err != nil {
+		return err
+	}
+
+	if err := drainNodesByLabelSelector(ctx, p.kubernetes, p.events, p.log, "karpenter.sh/nodepool="+name, p.drainOptions); err != nil {
+		return fmt.Errorf("draining node pool %s: %w", name, err)
+	}
+
+	claims, err := p.dynamic.Resource(karpenterNodeClaimGVR).List(ctx, metav1.ListOptions{
+		LabelSelector: "karpenter.sh/nodepool=" + name,
+	})
+	if err != nil {
+		return fmt.Errorf("listing node claims for pool %s: %w", name, err)
+	}
+
+	for _, claim := range claims.Items {
+		if err := p.dynamic.Resource(karpenterNodeClaimGVR).Delete(ctx, claim.GetName(), metav1.DeleteOptions{}); err != nil {
+			p.log.Error().Msgf("Deleting node claim %s: %s", claim.GetName(), err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (p *KarpenterProvider) getNodePoolLimits(ctx context.Context, name string) (map[string]string, error) {
+	obj, err := p.dynamic.Resource(karpenterNodePoolGVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	limits, found, err := unstructured.NestedStringMap(obj.Object, "spec", "limits")
+	if err != nil {
+		return nil, fmt.Errorf("reading spec.limits: %w", err)
+	}
+	if !found {
+		return map[string]string{}, nil
+	}
+
+	return limits, nil
+}
+
+// setNodePoolLimits merge-patches spec.limits to exactly the given resource
+// budget, deleting any existing key not present in limits. A JSON merge
+// patch only overwrites the keys it mentions, so an empty limits map alone
+// wouldn't clear an existing cpu/memory entry — the pool's current limits
+// are read first so stale keys can be explicitly nulled out.
+func (p *KarpenterProvider) setNodePoolLimits(ctx context.Context, name string, limits map[string]string) error {
+	if limits == nil {
+		limits = map[string]string{}
+	}
+
+	current, err := p.getNodePoolLimits(ctx, name)
+	if err != nil {
+		return fmt.Errorf("reading current limits for node pool %s: %w", name, err)
+	}
+
+	patchLimits := make(map[string]interface{}, len(current)+len(limits))
+	for key := range current {
+		patchLimits[key] = nil
+	}
+	for key, value := range limits {
+		patchLimits[key] = value
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"limits": patchLimits,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding limits patch: %w", err)
+	}
+
+	_, err = p.dynamic.Resource(karpenterNodePoolGVR).Patch(ctx, name, types.MergePatchType, body, metav1.PatchOptions{})
+	return err
+}
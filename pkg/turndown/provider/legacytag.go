@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	turndownv1alpha1 "github.com/kubecost/cluster-turndown/v2/pkg/apis/turndown/v1alpha1"
+)
+
+// EKSNodeGroupPreviousKey is the cloud tag cluster-turndown used to stash a
+// pool's min/max/count before the TurndownState CRD existed. It's kept only
+// as a read path, so installations that crashed mid-turndown on the old
+// tag scheme can still be restored.
+const EKSNodeGroupPreviousKey = "cluster.turndown.previous"
+
+// legacySavedNodePool reads a pool's pre-turndown sizing from the legacy
+// cluster.turndown.previous tag, used before sizing was persisted to a
+// TurndownState CRD.
+func legacySavedNodePool(np cp.NodePool) (turndownv1alpha1.SavedNodePool, bool) {
+	rangeTag, ok := np.Tags()[EKSNodeGroupPreviousKey]
+	if !ok {
+		return turndownv1alpha1.SavedNodePool{}, false
+	}
+
+	min, max, count, ok := expandRange(rangeTag)
+	if !ok {
+		return turndownv1alpha1.SavedNodePool{}, false
+	}
+
+	return turndownv1alpha1.SavedNodePool{
+		Name:     np.Name(),
+		MinNodes: min,
+		MaxNodes: max,
+		Count:    count,
+	}, true
+}
+
+func expandRange(s string) (min, max, count int32, ok bool) {
+	values := strings.Split(s, "/")
+	if len(values) != 3 {
+		return 0, 0, 0, false
+	}
+
+	c, err := strconv.Atoi(values[2])
+	if err != nil {
+		return 0, 0, 0, false
+	}
+
+	mn, err := strconv.Atoi(values[0])
+	if err != nil {
+		mn = c
+	}
+
+	mx, err := strconv.Atoi(values[1])
+	if err != nil {
+		mx = c
+	}
+
+	return int32(mn), int32(mx), int32(c), true
+}
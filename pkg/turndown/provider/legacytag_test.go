@@ -0,0 +1,34 @@
+package provider
+
+import "testing"
+
+func TestExpandRange(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		wantMin   int32
+		wantMax   int32
+		wantCount int32
+		wantOK    bool
+	}{
+		{name: "well formed", input: "1/5/3", wantMin: 1, wantMax: 5, wantCount: 3, wantOK: true},
+		{name: "missing fields", input: "1/5", wantOK: false},
+		{name: "garbage count", input: "1/5/x", wantOK: false},
+		{name: "garbage min falls back to count", input: "x/5/3", wantMin: 3, wantMax: 5, wantCount: 3, wantOK: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			min, max, count, ok := expandRange(c.input)
+			if ok != c.wantOK {
+				t.Fatalf("expandRange(%q) ok = %v, want %v", c.input, ok, c.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if min != c.wantMin || max != c.wantMax || count != c.wantCount {
+				t.Errorf("expandRange(%q) = (%d, %d, %d), want (%d, %d, %d)", c.input, min, max, count, c.wantMin, c.wantMax, c.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NodeGroupSpec describes one additional, named node group a
+// TurndownSchedule wants created on turn-up, beyond the singleton
+// cluster-turndown pool.
+type NodeGroupSpec struct {
+	Name         string
+	InstanceType string
+	MinNodes     int32
+	MaxNodes     int32
+}
+
+// ParseNodeGroupSizes parses the `sizes` map of a TurndownSchedule, where
+// each value uses the DSL `instanceType:min-max`, e.g.
+// `{"api": "m5.large:1-3", "workers": "m5.xlarge:2-10"}`.
+func ParseNodeGroupSizes(sizes map[string]string) ([]NodeGroupSpec, error) {
+	specs := make([]NodeGroupSpec, 0, len(sizes))
+
+	for name, dsl := range sizes {
+		spec, err := parseNodeGroupDSL(name, dsl)
+		if err != nil {
+			return nil, err
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+func parseNodeGroupDSL(name, dsl string) (NodeGroupSpec, error) {
+	typeAndRange := strings.SplitN(dsl, ":", 2)
+	if len(typeAndRange) != 2 {
+		return NodeGroupSpec{}, fmt.Errorf("invalid node group spec %q for %q, expected \"type:min-max\"", dsl, name)
+	}
+
+	instanceType := typeAndRange[0]
+	minMax := strings.SplitN(typeAndRange[1], "-", 2)
+	if len(minMax) != 2 {
+		return NodeGroupSpec{}, fmt.Errorf("invalid min-max range %q for %q, expected \"min-max\"", typeAndRange[1], name)
+	}
+
+	min, err := strconv.Atoi(minMax[0])
+	if err != nil {
+		return NodeGroupSpec{}, fmt.Errorf("invalid min %q for %q: %w", minMax[0], name, err)
+	}
+
+	max, err := strconv.Atoi(minMax[1])
+	if err != nil {
+		return NodeGroupSpec{}, fmt.Errorf("invalid max %q for %q: %w", minMax[1], name, err)
+	}
+
+	if min < 0 || max < min {
+		return NodeGroupSpec{}, fmt.Errorf("invalid range %d-%d for %q, expected 0 <= min <= max", min, max, name)
+	}
+
+	return NodeGroupSpec{
+		Name:         name,
+		InstanceType: instanceType,
+		MinNodes:     int32(min),
+		MaxNodes:     int32(max),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package provider
+
+import "testing"
+
+func TestParseNodeGroupDSL(t *testing.T) {
+	cases := []struct {
+		name    string
+		dsl     string
+		want    NodeGroupSpec
+		wantErr bool
+	}{
+		{
+			name: "well formed",
+			dsl:  "m5.large:1-3",
+			want: NodeGroupSpec{Name: "api", InstanceType: "m5.large", MinNodes: 1, MaxNodes: 3},
+		},
+		{
+			name: "zero min",
+			dsl:  "m5.xlarge:0-10",
+			want: NodeGroupSpec{Name: "api", InstanceType: "m5.xlarge", MinNodes: 0, MaxNodes: 10},
+		},
+		{
+			name:    "missing colon",
+			dsl:     "m5.large1-3",
+			wantErr: true,
+		},
+		{
+			name:    "missing dash",
+			dsl:     "m5.large:13",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric min",
+			dsl:     "m5.large:a-3",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric max",
+			dsl:     "m5.large:1-a",
+			wantErr: true,
+		},
+		{
+			name:    "max less than min",
+			dsl:     "m5.large:5-1",
+			wantErr: true,
+		},
+		{
+			name:    "negative min",
+			dsl:     "m5.large:-1-3",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseNodeGroupDSL("api", c.dsl)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("parseNodeGroupDSL(%q) expected error, got none", c.dsl)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseNodeGroupDSL(%q) unexpected error: %s", c.dsl, err.Error())
+			}
+			if got != c.want {
+				t.Errorf("parseNodeGroupDSL(%q) = %+v, want %+v", c.dsl, got, c.want)
+			}
+		})
+	}
+}
+
+func TestParseNodeGroupSizes(t *testing.T) {
+	specs, err := ParseNodeGroupSizes(map[string]string{
+		"api":     "m5.large:1-3",
+		"workers": "m5.xlarge:2-10",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if len(specs) != 2 {
+		t.Fatalf("expected 2 specs, got %d", len(specs))
+	}
+
+	if _, err := ParseNodeGroupSizes(map[string]string{"bad": "not-a-valid-spec"}); err == nil {
+		t.Error("expected error for malformed spec")
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	CapacityTypeOnDemand = "on-demand"
+	CapacityTypeSpot     = "spot"
+)
+
+// TurndownNodePoolSpec describes the shape of the node pool a ComputeProvider
+// should create to host the cluster-turndown workload. Providers are free to
+// ignore fields that don't apply to their cloud (e.g. AMIFamily on GKE).
+type TurndownNodePoolSpec struct {
+	InstanceType          string
+	CapacityType          string // CapacityTypeOnDemand or CapacityTypeSpot
+	AMIFamily             string
+	DiskType              string
+	DiskSizeGB            int32
+	Taints                []v1.Taint
+	SubnetIDs             []string
+	SecurityGroupIDs      []string
+	IAMInstanceProfileARN string
+}
+
+// DefaultEKSTurndownNodePoolSpec returns the spec cluster-turndown used to
+// hardcode for EKS before per-provider configuration was supported.
+func DefaultEKSTurndownNodePoolSpec() *TurndownNodePoolSpec {
+	return &TurndownNodePoolSpec{
+		InstanceType: "t2.small",
+		CapacityType: CapacityTypeOnDemand,
+		DiskType:     "gp2",
+		DiskSizeGB:   10,
+	}
+}
+
+// DefaultGKETurndownNodePoolSpec mirrors the EKS default, sized for GCP's
+// equivalent low-end general purpose instance type and disk.
+func DefaultGKETurndownNodePoolSpec() *TurndownNodePoolSpec {
+	return &TurndownNodePoolSpec{
+		InstanceType: "e2-small",
+		CapacityType: CapacityTypeOnDemand,
+		DiskType:     "pd-standard",
+		DiskSizeGB:   10,
+	}
+}
+
+// DefaultAKSTurndownNodePoolSpec mirrors the EKS default, sized for Azure's
+// equivalent low-end general purpose instance type and disk.
+func DefaultAKSTurndownNodePoolSpec() *TurndownNodePoolSpec {
+	return &TurndownNodePoolSpec{
+		InstanceType: "Standard_B2s",
+		CapacityType: CapacityTypeOnDemand,
+		DiskType:     "Standard_LRS",
+		DiskSizeGB:   10,
+	}
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultOrphanGracePeriod is how long a turndown-managed node pool is
+// allowed to exist with no nodes having joined the cluster before it's
+// considered a zombie from a crashed turn-up. Matches NodepoolDeletionDelay
+// in the turndown CLI/config docs.
+const DefaultOrphanGracePeriod = 30 * time.Minute
+
+var orphanPoolsReclaimed = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "turndown_orphan_pools_reclaimed_total",
+	Help: "Number of turndown-managed node pools garbage collected after being orphaned by a crashed turn-up.",
+})
+
+// ActiveScheduleLister reports the node pool names currently owned by a
+// live TurndownSchedule, so the reconciler doesn't delete pools that are
+// mid-turndown.
+type ActiveScheduleLister interface {
+	ActiveNodePoolNames() (map[string]bool, error)
+}
+
+// NodePoolReconciler periodically garbage collects turndown-managed node
+// pools that were left behind by a controller crash between CreateNodePool
+// and the schedule recording it, or by a failed turn-up.
+type NodePoolReconciler struct {
+	kubernetes      kubernetes.Interface
+	clusterProvider cp.ClusterProvider
+	scheduleLister  ActiveScheduleLister
+	gracePeriod     time.Duration
+	events          record.EventRecorder
+	log             zerolog.Logger
+}
+
+func NewNodePoolReconciler(kubernetes kubernetes.Interface, clusterProvider cp.ClusterProvider, scheduleLister ActiveScheduleLister) *NodePoolReconciler {
+	return &NodePoolReconciler{
+		kubernetes:      kubernetes,
+		clusterProvider: clusterProvider,
+		scheduleLister:  scheduleLister,
+		gracePeriod:     DefaultOrphanGracePeriod,
+		events:          newEventRecorder(kubernetes, "NodePoolReconciler"),
+		log:             log.With().Str("component", "NodePoolReconciler").Logger(),
+	}
+}
+
+// SetNodepoolDeletionDelay overrides how long an orphaned node pool must sit
+// unclaimed (no active schedule, no nodes joined) before it's garbage
+// collected. Defaults to DefaultOrphanGracePeriod.
+func (r *NodePoolReconciler) SetNodepoolDeletionDelay(d time.Duration) {
+	r.gracePeriod = d
+}
+
+// Run blocks, reconciling every period until ctx is cancelled.
+func (r *NodePoolReconciler) Run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.reconcile(ctx); err != nil {
+				r.log.Error().Msgf("Reconciling node pools: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (r *NodePoolReconciler) reconcile(ctx context.Context) error {
+	pools, err := r.clusterProvider.GetNodePools()
+	if err != nil {
+		return err
+	}
+
+	active, err := r.scheduleLister.ActiveNodePoolNames()
+	if err != nil {
+		return err
+	}
+
+	nodes, err := r.kubernetes.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	joined := make(map[string]bool, len(nodes.Items))
+	for _, node := range nodes.Items {
+		joined[r.clusterProvider.GetNodePoolName(&node)] = true
+	}
+
+	for _, pool := range pools {
+		if !isTurndownManagedPool(pool) {
+			continue
+		}
+		if active[pool.Name()] {
+			continue
+		}
+		if joined[pool.Name()] {
+			continue
+		}
+		if time.Since(pool.CreatedAt()) < r.gracePeriod {
+			continue
+		}
+
+		r.log.Warn().Msgf("Garbage collecting orphaned node pool: %s", pool.Name())
+
+		poolRef := &v1.ObjectReference{Kind: "NodePool", Name: pool.Name()}
+
+		if err := r.clusterProvider.DeleteNodePool(ctx, pool.Name()); err != nil {
+			r.events.Eventf(poolRef, v1.EventTypeWarning, "OrphanGCFailed", "Failed to garbage collect orphaned node pool: %s", err.Error())
+			r.log.Error().Msgf("Deleting orphaned node pool %s: %s", pool.Name(), err.Error())
+			continue
+		}
+
+		r.events.Eventf(poolRef, v1.EventTypeNormal, "OrphanGC", "Garbage collected orphaned node pool after %s with no nodes joining", r.gracePeriod)
+		orphanPoolsReclaimed.Inc()
+	}
+
+	return nil
+}
+
+// isTurndownManagedPool reports whether pool was created by turndown: its
+// name is the singleton turndown pool's name or that name followed by "-"
+// and a suffix, it carries the TurndownManagedTagKey
+// CreateNodePools applies to additional named groups (whose names are
+// caller-supplied and have no recognizable prefix, e.g. "api"), or it still
+// carries the legacy cluster.turndown.previous tag from installations that
+// predate both.
+func isTurndownManagedPool(pool cp.NodePool) bool {
+	if pool.Name() == EKSTurndownPoolName || strings.HasPrefix(pool.Name(), EKSTurndownPoolName+"-") {
+		return true
+	}
+
+	tags := pool.Tags()
+	if tags[TurndownManagedTagKey] == "true" {
+		return true
+	}
+
+	_, ok := tags[EKSNodeGroupPreviousKey]
+	return ok
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+)
+
+// fakeNodePool is a minimal cp.NodePool stand-in covering only the methods
+// isTurndownManagedPool reads.
+type fakeNodePool struct {
+	name string
+	tags map[string]string
+}
+
+func (f fakeNodePool) Name() string         { return f.name }
+func (f fakeNodePool) MinNodes() int32      { return 0 }
+func (f fakeNodePool) MaxNodes() int32      { return 0 }
+func (f fakeNodePool) NodeCount() int32     { return 0 }
+func (f fakeNodePool) InstanceType() string { return "" }
+func (f fakeNodePool) Tags() map[string]string {
+	return f.tags
+}
+func (f fakeNodePool) CreatedAt() time.Time { return time.Time{} }
+
+func TestIsTurndownManagedPool(t *testing.T) {
+	cases := []struct {
+		name string
+		pool cp.NodePool
+		want bool
+	}{
+		{
+			name: "singleton pool name",
+			pool: fakeNodePool{name: EKSTurndownPoolName},
+			want: true,
+		},
+		{
+			name: "singleton pool name prefix",
+			pool: fakeNodePool{name: EKSTurndownPoolName + "-api"},
+			want: true,
+		},
+		{
+			name: "legacy tag match",
+			pool: fakeNodePool{name: "some-other-pool", tags: map[string]string{EKSNodeGroupPreviousKey: "1/3/2"}},
+			want: true,
+		},
+		{
+			name: "named group via managed tag",
+			pool: fakeNodePool{name: "api", tags: map[string]string{TurndownManagedTagKey: "true"}},
+			want: true,
+		},
+		{
+			name: "unmanaged pool",
+			pool: fakeNodePool{name: "unrelated-pool"},
+			want: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isTurndownManagedPool(c.pool); got != c.want {
+				t.Errorf("isTurndownManagedPool(%q) = %v, want %v", c.pool.Name(), got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	cp "github.com/kubecost/cluster-turndown/v2/pkg/cluster/provider"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ProviderDeps bundles everything a ProviderFactory might need to build a
+// TurndownProvider. Individual backends use only the fields relevant to
+// them, e.g. KarpenterProvider ignores ClusterProvider and EKSProvider
+// ignores Dynamic.
+type ProviderDeps struct {
+	Kubernetes      kubernetes.Interface
+	ClusterProvider cp.ClusterProvider
+	Dynamic         dynamic.Interface
+	StateStore      TurndownStateStore
+
+	// Namespace is the namespace MachineDeployments live in for
+	// ClusterAPIProvider. Ignored by every other backend.
+	Namespace string
+}
+
+// ProviderFactory builds a TurndownProvider for a named ComputeProvider
+// backend, e.g. "eks", "gke", "aks", or "karpenter".
+type ProviderFactory func(deps ProviderDeps) (TurndownProvider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]ProviderFactory{}
+)
+
+// RegisterProvider makes a ComputeProvider backend available under name.
+// It's expected to be called from an init() function in the package that
+// implements the backend.
+func RegisterProvider(name string, factory ProviderFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[name] = factory
+}
+
+// NewProvider constructs the TurndownProvider registered under name.
+func NewProvider(name string, deps ProviderDeps) (TurndownProvider, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no ComputeProvider registered under name %q", name)
+	}
+
+	return factory(deps)
+}
+
+func init() {
+	RegisterProvider("eks", func(deps ProviderDeps) (TurndownProvider, error) {
+		return NewEKSProvider(deps.Kubernetes, deps.ClusterProvider, deps.StateStore), nil
+	})
+
+	RegisterProvider("gke", func(deps ProviderDeps) (TurndownProvider, error) {
+		return NewGKEProvider(deps.Kubernetes, deps.ClusterProvider, deps.StateStore), nil
+	})
+
+	RegisterProvider("aks", func(deps ProviderDeps) (TurndownProvider, error) {
+		return NewAKSProvider(deps.Kubernetes, deps.ClusterProvider, deps.StateStore), nil
+	})
+
+	RegisterProvider("karpenter", func(deps ProviderDeps) (TurndownProvider, error) {
+		if deps.Dynamic == nil {
+			return nil, fmt.Errorf("karpenter provider requires a dynamic client")
+		}
+
+		return NewKarpenterProvider(deps.Kubernetes, deps.Dynamic, deps.StateStore), nil
+	})
+
+	RegisterProvider("clusterapi", func(deps ProviderDeps) (TurndownProvider, error) {
+		if deps.Dynamic == nil {
+			return nil, fmt.Errorf("clusterapi provider requires a dynamic client")
+		}
+
+		return NewClusterAPIProvider(deps.Kubernetes, deps.Dynamic, deps.Namespace, deps.StateStore), nil
+	})
+}
+
+// clusterAPIResource and karpenterResource are the CRDs DetectProviderName
+// probes for, in preference order.
+var (
+	clusterAPIResource = machineDeploymentGVR
+	karpenterResource  = karpenterNodePoolGVR
+)
+
+// DetectProviderName inspects the cluster's installed APIs to decide which
+// ComputeProvider backend applies, for installs that don't pin one via
+// config. Cluster API is preferred when both CRDs are present, since a
+// cluster-api-provisioned cluster may still run Karpenter as its own
+// autoscaler underneath.
+func DetectProviderName(discoveryClient discovery.DiscoveryInterface) (string, error) {
+	if resourceExists(discoveryClient, clusterAPIResource) {
+		return "clusterapi", nil
+	}
+
+	if resourceExists(discoveryClient, karpenterResource) {
+		return "karpenter", nil
+	}
+
+	return "", fmt.Errorf("no supported ComputeProvider CRDs (cluster-api, karpenter) found on the cluster")
+}
+
+func resourceExists(discoveryClient discovery.DiscoveryInterface, gvr schema.GroupVersionResource) bool {
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvr.Resource {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRegisterProviderAndNewProvider(t *testing.T) {
+	RegisterProvider("test-echo", func(deps ProviderDeps) (TurndownProvider, error) {
+		return NewKarpenterProvider(deps.Kubernetes, deps.Dynamic, deps.StateStore), nil
+	})
+
+	if _, err := NewProvider("test-echo", ProviderDeps{Kubernetes: k8sfake.NewSimpleClientset()}); err != nil {
+		t.Fatalf("NewProvider(%q) unexpected error: %s", "test-echo", err.Error())
+	}
+
+	if _, err := NewProvider("not-registered", ProviderDeps{}); err == nil {
+		t.Error("NewProvider(\"not-registered\") expected error, got none")
+	}
+}
+
+func TestNewProviderKarpenterRequiresDynamicClient(t *testing.T) {
+	if _, err := NewProvider("karpenter", ProviderDeps{}); err == nil {
+		t.Error("expected error when Dynamic client is nil")
+	}
+}
+
+func TestNewProviderClusterAPIRequiresDynamicClient(t *testing.T) {
+	if _, err := NewProvider("clusterapi", ProviderDeps{}); err == nil {
+		t.Error("expected error when Dynamic client is nil")
+	}
+}
+
+func TestDetectProviderName(t *testing.T) {
+	cases := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "cluster api present",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: clusterAPIResource.GroupVersion().String(), APIResources: []metav1.APIResource{{Name: clusterAPIResource.Resource}}},
+			},
+			want: "clusterapi",
+		},
+		{
+			name: "karpenter present",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: karpenterResource.GroupVersion().String(), APIResources: []metav1.APIResource{{Name: karpenterResource.Resource}}},
+			},
+			want: "karpenter",
+		},
+		{
+			name:    "neither present",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clientset := k8sfake.NewSimpleClientset()
+			clientset.Resources = c.resources
+
+			got, err := DetectProviderName(clientset.Discovery())
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if got != c.want {
+				t.Errorf("DetectProviderName() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package provider
+
+import (
+	"context"
+
+	turndownv1alpha1 "github.com/kubecost/cluster-turndown/v2/pkg/apis/turndown/v1alpha1"
+)
+
+// TurndownStateStore persists the pre-turndown sizing of node pools so it
+// can survive a controller restart. It's backed by a TurndownState custom
+// resource rather than cloud provider tags.
+type TurndownStateStore interface {
+	Save(ctx context.Context, scheduleName string, pool turndownv1alpha1.SavedNodePool) error
+	Load(ctx context.Context, scheduleName string, poolName string) (turndownv1alpha1.SavedNodePool, bool, error)
+	Delete(ctx context.Context, scheduleName string, poolName string) error
+}